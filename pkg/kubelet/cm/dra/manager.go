@@ -18,16 +18,23 @@ package dra
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
 
 	v1 "k8s.io/api/core/v1"
 	resourceapi "k8s.io/api/resource/v1alpha2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clientset "k8s.io/client-go/kubernetes"
+	resourcev1alpha2listers "k8s.io/client-go/listers/resource/v1alpha2"
 	"k8s.io/dynamic-resource-allocation/resourceclaim"
 	"k8s.io/klog/v2"
 	drapb "k8s.io/kubelet/pkg/apis/dra/v1alpha3"
+	"k8s.io/kubernetes/pkg/kubelet/cm/dra/metrics"
 	dra "k8s.io/kubernetes/pkg/kubelet/cm/dra/plugin"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 )
@@ -35,6 +42,28 @@ import (
 // draManagerStateFileName is the file name where dra manager stores its state
 const draManagerStateFileName = "dra_manager_state"
 
+// defaultReconcilePeriod is how often the reconciliation loop walks the
+// claimInfo cache looking for orphaned entries when no other period is
+// configured.
+const defaultReconcilePeriod = 60 * time.Second
+
+// maxParallelDRAPluginRequests bounds how many NodePrepareResources or
+// NodeUnprepareResources RPCs can be in flight across all plugins at once,
+// so that a pod referencing many drivers doesn't open an unbounded number
+// of concurrent gRPC calls.
+const maxParallelDRAPluginRequests = 10
+
+// defaultPluginTimeout is how long a single NodePrepareResources or
+// NodeUnprepareResources RPC to a plugin is allowed to run before it is
+// canceled, when no other timeout is configured.
+const defaultPluginTimeout = 45 * time.Second
+
+// ActivePodsFunc is a function that returns a list of pods the kubelet
+// considers active. It is supplied by the kubelet's cm setup and used by the
+// DRA manager's reconciliation loop to determine which claimInfo cache
+// entries are still owned by a live pod.
+type ActivePodsFunc func() []*v1.Pod
+
 // ManagerImpl is the structure in charge of managing DRA resource Plugins.
 type ManagerImpl struct {
 	// cache contains cached claim info
@@ -42,10 +71,62 @@ type ManagerImpl struct {
 
 	// KubeClient reference
 	kubeClient clientset.Interface
+
+	// activePods returns the current set of pods that the kubelet
+	// considers active. It is nil until Start is called.
+	activePods ActivePodsFunc
+
+	// reconcilePeriod is the interval between successive walks of the
+	// claimInfo cache looking for orphaned entries.
+	reconcilePeriod time.Duration
+
+	// pluginTimeout bounds how long a single NodePrepareResources or
+	// NodeUnprepareResources RPC to a plugin is allowed to run before it
+	// is canceled.
+	pluginTimeout time.Duration
+
+	// nodeName is the name of the node this kubelet is running on. It is
+	// used by the prewarm watchers to recognize PodSchedulingContexts that
+	// have selected this node.
+	nodeName types.NodeName
+
+	// prewarmMu guards prewarmed.
+	prewarmMu sync.RWMutex
+	// prewarmed holds ResourceClaims observed by the prewarm watcher,
+	// keyed by namespace/name, that PrepareResources can consult instead
+	// of issuing its own Get. Entries older than prewarmFreshness are
+	// ignored by prewarmedClaim and left for the next watcher update (or
+	// eviction pass) to replace or clean up.
+	prewarmed map[string]prewarmEntry
+
+	// claimLister backs prewarmedClaim's resync check: it lets a lookup
+	// compare the ResourceVersion it cached against the informer's current
+	// local view without waiting for that claim's own Add/Update handler
+	// to run. It is nil until StartPrewarm is called.
+	claimLister resourcev1alpha2listers.ResourceClaimLister
+
+	// subscribersMutex guards subscribers.
+	subscribersMutex sync.RWMutex
+	// subscribers holds the set of channels currently watching claim
+	// prepare/unprepare events via Subscribe.
+	subscribers map[chan *PodResourceClaimEvent]struct{}
+
+	// dynamicResourcesEnabled gates whether GetPodResources,
+	// GetContainerResourceClaimInfo and Subscribe surface any data. It is
+	// meant to be threaded in from the kubelet's cm setup, resolved from the
+	// feature gate controlling whether the podresources gRPC server's
+	// DynamicResources field is wired up — but that server-side field,
+	// its proto message, and the feature gate itself all live in
+	// pkg/kubelet/apis/podresources and pkg/features, neither of which
+	// exists in this checkout. Until that wiring lands, this flag has no
+	// caller to set it to true and DynamicResourcesLister is unreachable;
+	// it exists so the gRPC-side wiring is a small, additive change once
+	// that package is available, rather than a second pass through this one.
+	dynamicResourcesEnabled bool
 }
 
 // NewManagerImpl creates a new manager.
-func NewManagerImpl(kubeClient clientset.Interface, stateFileDirectory string, nodeName types.NodeName) (*ManagerImpl, error) {
+func NewManagerImpl(kubeClient clientset.Interface, stateFileDirectory string, nodeName types.NodeName, dynamicResourcesEnabled bool) (*ManagerImpl, error) {
 	klog.V(2).InfoS("Creating DRA manager")
 
 	claimInfoCache, err := newClaimInfoCache(stateFileDirectory, draManagerStateFileName)
@@ -53,19 +134,45 @@ func NewManagerImpl(kubeClient clientset.Interface, stateFileDirectory string, n
 		return nil, fmt.Errorf("failed to create claimInfo cache: %+v", err)
 	}
 
+	metrics.Register()
+
 	manager := &ManagerImpl{
-		cache:      claimInfoCache,
-		kubeClient: kubeClient,
+		cache:                   claimInfoCache,
+		kubeClient:              kubeClient,
+		reconcilePeriod:         defaultReconcilePeriod,
+		pluginTimeout:           defaultPluginTimeout,
+		nodeName:                nodeName,
+		prewarmed:               make(map[string]prewarmEntry),
+		subscribers:             make(map[chan *PodResourceClaimEvent]struct{}),
+		dynamicResourcesEnabled: dynamicResourcesEnabled,
 	}
 
 	return manager, nil
 }
 
+// Start wires up the active pods getter supplied by the kubelet's cm setup
+// and launches the background reconciliation loop. It must be called once,
+// after the kubelet's pod manager is available, and before the manager is
+// otherwise considered ready.
+func (m *ManagerImpl) Start(ctx context.Context, activePods ActivePodsFunc) {
+	m.activePods = activePods
+	go m.Reconcile(ctx)
+}
+
 // PrepareResources attempts to prepare all of the required resource
 // plugin resources for the input container, issue NodePrepareResources rpc requests
 // for each new resource requirement, process their responses and update the cached
 // containerResources on success.
 func (m *ManagerImpl) PrepareResources(pod *v1.Pod) (rerr error) {
+	// dispatchedClaims records, by claim UID, which claims were handed off
+	// to callNodePrepareResources. Once that happens, a non-nil rerr may
+	// simply reflect some *other* claim's plugin failing, and cleanup of
+	// a dispatched claim is handled individually, per its own result,
+	// below — so the blanket per-claim defers further down must not
+	// re-fire for it. Claims that turned out to already be prepared never
+	// get dispatched, so they still need the blanket defer to clean up
+	// their pod reference if some other claim in the pod fails.
+	dispatchedClaims := make(map[types.UID]bool)
 	batches := make(map[string][]*drapb.Claim)
 	resourceClaims := make(map[types.UID]*resourceapi.ResourceClaim)
 	for i := range pod.Spec.ResourceClaims {
@@ -80,13 +187,22 @@ func (m *ManagerImpl) PrepareResources(pod *v1.Pod) (rerr error) {
 			// Nothing to do.
 			continue
 		}
-		// Query claim object from the API server
-		resourceClaim, err := m.kubeClient.ResourceV1alpha2().ResourceClaims(pod.Namespace).Get(
-			context.TODO(),
-			*claimName,
-			metav1.GetOptions{})
-		if err != nil {
-			return fmt.Errorf("failed to fetch ResourceClaim %s referenced by pod %s: %+v", *claimName, pod.Name, err)
+		// Use the copy the prewarm watcher cached when the scheduler
+		// finalized allocation, if one is still fresh, instead of paying
+		// for a synchronous Get on the admission hot path.
+		resourceClaim := m.prewarmedClaim(*claimName, pod.Namespace)
+		if resourceClaim != nil {
+			metrics.PrewarmCacheHitsTotal.Inc()
+		} else {
+			metrics.PrewarmCacheMissesTotal.Inc()
+			var err error
+			resourceClaim, err = m.kubeClient.ResourceV1alpha2().ResourceClaims(pod.Namespace).Get(
+				context.TODO(),
+				*claimName,
+				metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to fetch ResourceClaim %s referenced by pod %s: %+v", *claimName, pod.Name, err)
+			}
 		}
 
 		if mustCheckOwner {
@@ -111,7 +227,7 @@ func (m *ManagerImpl) PrepareResources(pod *v1.Pod) (rerr error) {
 		// Add a defer to make sure we remove references to this pod in the
 		// claimInfo cache in cases where this function returns an error.
 		defer func(claim *resourceapi.ResourceClaim) {
-			if rerr != nil {
+			if rerr != nil && !dispatchedClaims[claim.UID] {
 				m.cache.Lock()
 				claimInfo, exists := m.cache.get(claim.Name, claim.Namespace)
 				if exists {
@@ -149,12 +265,13 @@ func (m *ManagerImpl) PrepareResources(pod *v1.Pod) (rerr error) {
 			// This saved claim will be used to update ClaimInfo cache
 			// after NodePrepareResources GRPC succeeds
 			resourceClaims[claimInfo.ClaimUID] = resourceClaim
+			dispatchedClaims[resourceClaim.UID] = true
 
 			// Loop through all plugins and prepare for calling NodePrepareResources.
 			for _, resourceHandle := range claimInfo.ResourceHandles {
 				// If no DriverName is provided in the resourceHandle, we
 				// use the DriverName from the status
-				pluginName := claimInfo.DriverName
+				pluginName := resourceHandle.DriverName
 				if pluginName == "" {
 					pluginName = claimInfo.DriverName
 				}
@@ -177,68 +294,48 @@ func (m *ManagerImpl) PrepareResources(pod *v1.Pod) (rerr error) {
 		}
 	}
 
-	// Call NodePrepareResources for all claims in each batch.
-	// If there is any error, processing gets aborted.
-	// We could try to continue, but that would make the code more complex.
-	for pluginName, claims := range batches {
-		// Call NodePrepareResources RPC for all resource handles.
-		client, err := dra.NewDRAPluginClient(pluginName)
-		if err != nil {
-			return fmt.Errorf("failed to get DRA Plugin client for plugin name %s: %v", pluginName, err)
-		}
-		response, err := client.NodePrepareResources(context.Background(), &drapb.NodePrepareResourcesRequest{Claims: claims})
-		if err != nil {
-			// General error unrelated to any particular claim.
-			return fmt.Errorf("NodePrepareResources failed: %v", err)
-		}
-		for claimUID, result := range response.Claims {
-			reqClaim := lookupClaimRequest(claims, claimUID)
-			if reqClaim == nil {
-				return fmt.Errorf("NodePrepareResources returned result for unknown claim UID %s", claimUID)
-			}
-			if result.GetError() != "" {
-				return fmt.Errorf("NodePrepareResources failed for claim %s/%s: %s", reqClaim.Namespace, reqClaim.Name, result.Error)
-			}
-
-			claim := resourceClaims[types.UID(claimUID)]
-
-			// Atomically perform some operations on the claimInfo cache.
-			err := m.cache.withLock(func() error {
-				// Add the prepared CDI devices to the claim info
-				info, exists := m.cache.get(claim.Name, claim.Namespace)
-				if !exists {
-					return fmt.Errorf("unable to get claim info for claim %s in namespace %s", claim.Name, claim.Namespace)
-				}
-				if err := info.setCDIDevices(pluginName, result.GetCDIDevices()); err != nil {
-					return fmt.Errorf("unable to add CDI devices for plugin %s of claim %s in namespace %s", pluginName, claim.Name, claim.Namespace)
-				}
-				return nil
-			})
-			if err != nil {
-				return fmt.Errorf("locked cache operation: %w", err)
-			}
-		}
-
-		unfinished := len(claims) - len(response.Claims)
-		if unfinished != 0 {
-			return fmt.Errorf("NodePrepareResources left out %d claims", unfinished)
-		}
-	}
+	// Call NodePrepareResources for all claims in each batch, dispatching
+	// each plugin's batch to its own goroutine so that a slow or failing
+	// plugin cannot hold up pods that only depend on other plugins.
+	results := m.callNodePrepareResources(batches)
 
 	// Atomically perform some operations on the claimInfo cache.
+	var errs []error
 	err := m.cache.withLock(func() error {
-		// Mark all pod claims as prepared.
-		for _, claim := range resourceClaims {
+		for claimUID, claim := range resourceClaims {
 			info, exists := m.cache.get(claim.Name, claim.Namespace)
 			if !exists {
-				return fmt.Errorf("unable to get claim info for claim %s in namespace %s", claim.Name, claim.Namespace)
+				errs = append(errs, fmt.Errorf("unable to get claim info for claim %s in namespace %s", claim.Name, claim.Namespace))
+				continue
 			}
+
+			// A claim with no ResourceHandles never got a batch entry
+			// and so has no entry in results; splitPrepareResults treats
+			// that as nothing to wait on from any plugin, i.e. success.
+			succeeded, err := splitPrepareResults(claim.Namespace, claim.Name, results[claimUID])
+			failed := err != nil
+			if err != nil {
+				errs = append(errs, err)
+			}
+			for _, result := range succeeded {
+				if err := info.setCDIDevices(result.pluginName, result.cdiDevices); err != nil {
+					errs = append(errs, fmt.Errorf("unable to add CDI devices for plugin %s of claim %s in namespace %s: %w", result.pluginName, claim.Name, claim.Namespace, err))
+					failed = true
+				}
+			}
+			if failed {
+				info.deletePodReference(pod.UID)
+				continue
+			}
+
 			info.setPrepared()
+			m.publishClaimEvent(PodResourceClaimEventPrepared, info)
 		}
 
-		// Checkpoint to ensure all prepared claims are tracked.
+		// Checkpoint to ensure all prepared claims are tracked, even if
+		// some of the claims above failed.
 		if err := m.cache.syncToCheckpoint(); err != nil {
-			return fmt.Errorf("failed to checkpoint claimInfo state: %w", err)
+			errs = append(errs, fmt.Errorf("failed to checkpoint claimInfo state: %w", err))
 		}
 
 		return nil
@@ -247,16 +344,101 @@ func (m *ManagerImpl) PrepareResources(pod *v1.Pod) (rerr error) {
 		return fmt.Errorf("locked cache operation: %w", err)
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
-func lookupClaimRequest(claims []*drapb.Claim, claimUID string) *drapb.Claim {
-	for _, claim := range claims {
-		if claim.Uid == claimUID {
-			return claim
+// preparePluginResult holds the outcome of a single plugin's
+// NodePrepareResources call for a single claim.
+type preparePluginResult struct {
+	pluginName string
+	cdiDevices []string
+	err        error
+}
+
+// splitPrepareResults partitions a claim's per-plugin NodePrepareResources
+// results into the ones that succeeded (whose CDI devices the caller still
+// needs to record against the claimInfo cache) and a single error joining
+// every plugin that failed. A nil/empty results slice — a claim with no
+// ResourceHandles, which never gets a batch entry — has nothing to wait on
+// from any plugin and is treated as full success.
+func splitPrepareResults(namespace, name string, results []*preparePluginResult) (succeeded []*preparePluginResult, err error) {
+	var errs []error
+	for _, result := range results {
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("NodePrepareResources failed for claim %s/%s on plugin %s: %w", namespace, name, result.pluginName, result.err))
+			continue
 		}
+		succeeded = append(succeeded, result)
+	}
+	return succeeded, errors.Join(errs...)
+}
+
+// callNodePrepareResources dispatches NodePrepareResources to each plugin in
+// batches concurrently, bounding the number of in-flight plugin RPCs and
+// applying a per-plugin timeout so that a single hung plugin cannot block
+// pod admission indefinitely. The result map is keyed by claim UID so that
+// the caller can process per-claim successes and failures independently of
+// which plugin(s) they came from. A claim whose ResourceHandles span
+// multiple drivers appears in more than one plugin's batch, so each claim
+// UID maps to one result per plugin that was asked to prepare it, not a
+// single overwritten result.
+func (m *ManagerImpl) callNodePrepareResources(batches map[string][]*drapb.Claim) map[types.UID][]*preparePluginResult {
+	results := make(map[types.UID][]*preparePluginResult, len(batches))
+	var resultsMu sync.Mutex
+
+	recordErr := func(claims []*drapb.Claim, pluginName string, err error) {
+		resultsMu.Lock()
+		defer resultsMu.Unlock()
+		for _, claim := range claims {
+			uid := types.UID(claim.Uid)
+			results[uid] = append(results[uid], &preparePluginResult{pluginName: pluginName, err: err})
+		}
+	}
+
+	g := new(errgroup.Group)
+	g.SetLimit(maxParallelDRAPluginRequests)
+	for pluginName, claims := range batches {
+		pluginName, claims := pluginName, claims
+		g.Go(func() error {
+			client, err := dra.NewDRAPluginClient(pluginName)
+			if err != nil {
+				recordErr(claims, pluginName, fmt.Errorf("failed to get DRA Plugin client for plugin name %s: %w", pluginName, err))
+				return nil
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), m.pluginTimeout)
+			defer cancel()
+			response, err := client.NodePrepareResources(ctx, &drapb.NodePrepareResourcesRequest{Claims: claims})
+			if err != nil {
+				// General error unrelated to any particular claim.
+				recordErr(claims, pluginName, fmt.Errorf("NodePrepareResources failed: %w", err))
+				return nil
+			}
+
+			resultsMu.Lock()
+			defer resultsMu.Unlock()
+			for _, claim := range claims {
+				uid := types.UID(claim.Uid)
+				result, ok := response.Claims[claim.Uid]
+				if !ok {
+					results[uid] = append(results[uid], &preparePluginResult{pluginName: pluginName, err: fmt.Errorf("NodePrepareResources left out claim %s/%s", claim.Namespace, claim.Name)})
+					continue
+				}
+				if result.GetError() != "" {
+					results[uid] = append(results[uid], &preparePluginResult{pluginName: pluginName, err: fmt.Errorf("NodePrepareResources failed for claim %s/%s: %s", claim.Namespace, claim.Name, result.GetError())})
+					continue
+				}
+				results[uid] = append(results[uid], &preparePluginResult{pluginName: pluginName, cdiDevices: result.GetCDIDevices()})
+			}
+			return nil
+		})
 	}
-	return nil
+	// Every g.Go above always returns nil and records its outcome directly
+	// into results, so Wait only ever blocks until all plugins have
+	// finished; it has no error of its own to surface.
+	_ = g.Wait()
+
+	return results
 }
 
 func claimIsUsedByPod(podClaim *v1.PodResourceClaim, pod *v1.Pod) bool {
@@ -339,8 +521,7 @@ func (m *ManagerImpl) GetResources(pod *v1.Pod, container *v1.Container) (*Conta
 // As such, calls to the underlying NodeUnprepareResource API are skipped for claims that have
 // already been successfully unprepared.
 func (m *ManagerImpl) UnprepareResources(pod *v1.Pod) error {
-	batches := make(map[string][]*drapb.Claim)
-	claimNames := make(map[types.UID]string)
+	claimNames := make([]string, 0, len(pod.Spec.ResourceClaims))
 	for i := range pod.Spec.ResourceClaims {
 		claimName, _, err := resourceclaim.Name(pod, &pod.Spec.ResourceClaims[i])
 		if err != nil {
@@ -354,10 +535,25 @@ func (m *ManagerImpl) UnprepareResources(pod *v1.Pod) error {
 			continue
 		}
 
+		claimNames = append(claimNames, *claimName)
+	}
+
+	return m.unprepareResources(pod.UID, pod.Namespace, claimNames)
+}
+
+// unprepareResources calls a plugin's NodeUnprepareResource API for the named
+// claims in namespace that are owned by podUID. It contains the batching,
+// checkpointing and cache-locking logic shared by UnprepareResources and the
+// orphaned-claim reconciliation loop, both of which differ only in how they
+// arrive at the (podUID, namespace, claimNames) tuple.
+func (m *ManagerImpl) unprepareResources(podUID types.UID, namespace string, claimNames []string) error {
+	batches := make(map[string][]*drapb.Claim)
+	claimNamesByUID := make(map[types.UID]string)
+	for _, claimName := range claimNames {
 		// Atomically perform some operations on the claimInfo cache.
-		err = m.cache.withLock(func() error {
+		err := m.cache.withLock(func() error {
 			// Get the claim info from the cache
-			claimInfo, exists := m.cache.get(*claimName, pod.Namespace)
+			claimInfo, exists := m.cache.get(claimName, namespace)
 
 			// Skip calling NodeUnprepareResource if claim info is not cached
 			if !exists {
@@ -372,13 +568,13 @@ func (m *ManagerImpl) UnprepareResources(pod *v1.Pod) error {
 				// if the checkpoint has succeeded. That means if the kubelet
 				// is ever restarted before this checkpoint succeeds, we will
 				// simply call into this (idempotent) function again.
-				claimInfo.deletePodReference(pod.UID)
+				claimInfo.deletePodReference(podUID)
 				return nil
 			}
 
 			// This claimInfo name will be used to update ClaimInfo cache
 			// after NodeUnprepareResources GRPC succeeds
-			claimNames[claimInfo.ClaimUID] = claimInfo.ClaimName
+			claimNamesByUID[claimInfo.ClaimUID] = claimInfo.ClaimName
 
 			// Loop through all plugins and prepare for calling NodeUnprepareResources.
 			for _, resourceHandle := range claimInfo.ResourceHandles {
@@ -408,64 +604,131 @@ func (m *ManagerImpl) UnprepareResources(pod *v1.Pod) error {
 		}
 	}
 
-	// Call NodeUnprepareResources for all claims in each batch.
-	// If there is any error, processing gets aborted.
-	// We could try to continue, but that would make the code more complex.
-	for pluginName, claims := range batches {
-		// Call NodeUnprepareResources RPC for all resource handles.
-		client, err := dra.NewDRAPluginClient(pluginName)
-		if err != nil {
-			return fmt.Errorf("failed to get DRA Plugin client for plugin name %s: %v", pluginName, err)
-		}
-		response, err := client.NodeUnprepareResources(context.Background(), &drapb.NodeUnprepareResourcesRequest{Claims: claims})
-		if err != nil {
-			// General error unrelated to any particular claim.
-			return fmt.Errorf("NodeUnprepareResources failed: %v", err)
-		}
+	// Call NodeUnprepareResources for all claims in each batch, dispatching
+	// each plugin's batch to its own goroutine so that a slow or failing
+	// plugin cannot hold up the unprepare of claims belonging to other
+	// plugins.
+	results := m.callNodeUnprepareResources(batches)
 
-		for claimUID, result := range response.Claims {
-			reqClaim := lookupClaimRequest(claims, claimUID)
-			if reqClaim == nil {
-				return fmt.Errorf("NodeUnprepareResources returned result for unknown claim UID %s", claimUID)
-			}
-			if result.GetError() != "" {
-				return fmt.Errorf("NodeUnprepareResources failed for claim %s/%s: %s", reqClaim.Namespace, reqClaim.Name, result.Error)
+	// Atomically perform some operations on the claimInfo cache.
+	var errs []error
+	err := m.cache.withLock(func() error {
+		for claimUID, claimName := range claimNamesByUID {
+			// A claim with no ResourceHandles never got a batch entry
+			// and so has no entry in results; splitUnprepareResults treats
+			// that as nothing to wait on from any plugin, i.e. success.
+			if err := splitUnprepareResults(namespace, claimName, results[claimUID]); err != nil {
+				errs = append(errs, err)
+				continue
 			}
 
-			claimName := claimNames[types.UID(claimUID)]
-
-			// Atomically perform some operations on the claimInfo cache.
-			err := m.cache.withLock(func() error {
-				// Delete claim info from the cache only when unprepare succeeds.
-				// This ensures that the status manager doesn't enter termination status
-				// for the pod. This logic is implemented in
-				// m.PodMightNeedToUnprepareResources and claimInfo.hasPodReference.
-				m.cache.delete(claimName, pod.Namespace)
-				return nil
-			})
-			if err != nil {
-				return fmt.Errorf("locked cache operation: %w", err)
+			// Delete claim info from the cache only when unprepare succeeds.
+			// This ensures that the status manager doesn't enter termination status
+			// for the pod. This logic is implemented in
+			// m.PodMightNeedToUnprepareResources and claimInfo.hasPodReference.
+			info, exists := m.cache.get(claimName, namespace)
+			if exists {
+				m.publishClaimEvent(PodResourceClaimEventUnprepared, info)
 			}
+			m.cache.delete(claimName, namespace)
 		}
 
-		unfinished := len(claims) - len(response.Claims)
-		if unfinished != 0 {
-			return fmt.Errorf("NodeUnprepareResources left out %d claims", unfinished)
-		}
-	}
-
-	// Atomically perform some operations on the claimInfo cache.
-	err := m.cache.withRLock(func() error {
 		if err := m.cache.syncToCheckpoint(); err != nil {
-			return fmt.Errorf("failed to checkpoint claimInfo state: %w", err)
+			errs = append(errs, fmt.Errorf("failed to checkpoint claimInfo state: %w", err))
 		}
+
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("locked cache operation: %w", err)
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// unpreparePluginResult holds the outcome of a single plugin's
+// NodeUnprepareResources call for a single claim.
+type unpreparePluginResult struct {
+	pluginName string
+	err        error
+}
+
+// splitUnprepareResults returns a single error joining every plugin that
+// failed to unprepare a claim, or nil if all of them (or none, for a claim
+// with no ResourceHandles) succeeded.
+func splitUnprepareResults(namespace, name string, results []*unpreparePluginResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("NodeUnprepareResources failed for claim %s/%s on plugin %s: %w", namespace, name, result.pluginName, result.err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// callNodeUnprepareResources dispatches NodeUnprepareResources to each
+// plugin in batches concurrently, with the same bounded concurrency and
+// per-plugin timeout as callNodePrepareResources. As with
+// callNodePrepareResources, a claim whose ResourceHandles span multiple
+// drivers appears in more than one plugin's batch, so each claim UID maps
+// to one result per plugin that was asked to unprepare it.
+func (m *ManagerImpl) callNodeUnprepareResources(batches map[string][]*drapb.Claim) map[types.UID][]*unpreparePluginResult {
+	results := make(map[types.UID][]*unpreparePluginResult, len(batches))
+	var resultsMu sync.Mutex
+
+	recordErr := func(claims []*drapb.Claim, pluginName string, err error) {
+		resultsMu.Lock()
+		defer resultsMu.Unlock()
+		for _, claim := range claims {
+			uid := types.UID(claim.Uid)
+			results[uid] = append(results[uid], &unpreparePluginResult{pluginName: pluginName, err: err})
+		}
+	}
+
+	g := new(errgroup.Group)
+	g.SetLimit(maxParallelDRAPluginRequests)
+	for pluginName, claims := range batches {
+		pluginName, claims := pluginName, claims
+		g.Go(func() error {
+			client, err := dra.NewDRAPluginClient(pluginName)
+			if err != nil {
+				recordErr(claims, pluginName, fmt.Errorf("failed to get DRA Plugin client for plugin name %s: %w", pluginName, err))
+				return nil
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), m.pluginTimeout)
+			defer cancel()
+			response, err := client.NodeUnprepareResources(ctx, &drapb.NodeUnprepareResourcesRequest{Claims: claims})
+			if err != nil {
+				// General error unrelated to any particular claim.
+				recordErr(claims, pluginName, fmt.Errorf("NodeUnprepareResources failed: %w", err))
+				return nil
+			}
+
+			resultsMu.Lock()
+			defer resultsMu.Unlock()
+			for _, claim := range claims {
+				uid := types.UID(claim.Uid)
+				result, ok := response.Claims[claim.Uid]
+				if !ok {
+					results[uid] = append(results[uid], &unpreparePluginResult{pluginName: pluginName, err: fmt.Errorf("NodeUnprepareResources left out claim %s/%s", claim.Namespace, claim.Name)})
+					continue
+				}
+				if result.GetError() != "" {
+					results[uid] = append(results[uid], &unpreparePluginResult{pluginName: pluginName, err: fmt.Errorf("NodeUnprepareResources failed for claim %s/%s: %s", claim.Namespace, claim.Name, result.GetError())})
+					continue
+				}
+				results[uid] = append(results[uid], &unpreparePluginResult{pluginName: pluginName})
+			}
+			return nil
+		})
+	}
+	// Every g.Go above always returns nil and records its outcome directly
+	// into results, so Wait only ever blocks until all plugins have
+	// finished; it has no error of its own to surface.
+	_ = g.Wait()
+
+	return results
 }
 
 // PodMightNeedToUnprepareResources returns true if the pod might need to
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestSplitPrepareResults(t *testing.T) {
+	errPlugin := errors.New("plugin unavailable")
+
+	for name, test := range map[string]struct {
+		results       []*preparePluginResult
+		wantSucceeded []*preparePluginResult
+		wantErr       bool
+	}{
+		"no resource handles": {
+			results:       nil,
+			wantSucceeded: nil,
+			wantErr:       false,
+		},
+		"single plugin succeeds": {
+			results: []*preparePluginResult{
+				{pluginName: "driver-a", cdiDevices: []string{"vendor.com/device=0"}},
+			},
+			wantSucceeded: []*preparePluginResult{
+				{pluginName: "driver-a", cdiDevices: []string{"vendor.com/device=0"}},
+			},
+			wantErr: false,
+		},
+		"one of two plugins fails": {
+			results: []*preparePluginResult{
+				{pluginName: "driver-a", cdiDevices: []string{"vendor.com/device=0"}},
+				{pluginName: "driver-b", err: errPlugin},
+			},
+			wantSucceeded: []*preparePluginResult{
+				{pluginName: "driver-a", cdiDevices: []string{"vendor.com/device=0"}},
+			},
+			wantErr: true,
+		},
+		"all plugins fail": {
+			results: []*preparePluginResult{
+				{pluginName: "driver-a", err: errPlugin},
+				{pluginName: "driver-b", err: errPlugin},
+			},
+			wantSucceeded: nil,
+			wantErr:       true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			succeeded, err := splitPrepareResults("default", "claim-1", test.results)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("splitPrepareResults() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr && !errors.Is(err, errPlugin) {
+				t.Errorf("splitPrepareResults() error %v does not wrap the originating plugin error", err)
+			}
+			if len(succeeded) != len(test.wantSucceeded) {
+				t.Fatalf("splitPrepareResults() succeeded = %v, want %v", succeeded, test.wantSucceeded)
+			}
+			for i, result := range succeeded {
+				if result.pluginName != test.wantSucceeded[i].pluginName {
+					t.Errorf("succeeded[%d].pluginName = %q, want %q", i, result.pluginName, test.wantSucceeded[i].pluginName)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitUnprepareResults(t *testing.T) {
+	errPlugin := errors.New("plugin unavailable")
+
+	for name, test := range map[string]struct {
+		results []*unpreparePluginResult
+		wantErr bool
+	}{
+		"no resource handles": {
+			results: nil,
+			wantErr: false,
+		},
+		"single plugin succeeds": {
+			results: []*unpreparePluginResult{{pluginName: "driver-a"}},
+			wantErr: false,
+		},
+		"one of two plugins fails": {
+			results: []*unpreparePluginResult{
+				{pluginName: "driver-a"},
+				{pluginName: "driver-b", err: errPlugin},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := splitUnprepareResults("default", "claim-1", test.results)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("splitUnprepareResults() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr && !errors.Is(err, errPlugin) {
+				t.Errorf("splitUnprepareResults() error %v does not wrap the originating plugin error", err)
+			}
+		})
+	}
+}
+
+// Guard against a regression of the "last writer wins" bug: a claim with
+// results from two plugins must not silently drop one of them, whichever
+// order the plugin goroutines happened to append them in.
+func TestSplitPrepareResultsPreservesAllPluginOutcomes(t *testing.T) {
+	results := []*preparePluginResult{
+		{pluginName: "driver-a", cdiDevices: []string{"vendor.com/device=0"}},
+		{pluginName: "driver-b", cdiDevices: []string{"vendor.com/device=1"}},
+	}
+
+	succeeded, err := splitPrepareResults("default", "claim-1", results)
+	if err != nil {
+		t.Fatalf("splitPrepareResults() unexpected error: %v", err)
+	}
+	if len(succeeded) != 2 {
+		t.Fatalf("splitPrepareResults() returned %d results, want 2: %v", len(succeeded), succeeded)
+	}
+}
+
+// The same "last writer wins" guarantee must hold for unprepare: a claim
+// whose handles span two drivers must not have one driver's failure hidden
+// by the other driver's success.
+func TestSplitUnprepareResultsPreservesAllPluginOutcomes(t *testing.T) {
+	errPlugin := errors.New("plugin unavailable")
+	results := []*unpreparePluginResult{
+		{pluginName: "driver-a"},
+		{pluginName: "driver-b", err: errPlugin},
+	}
+
+	if err := splitUnprepareResults("default", "claim-1", results); !errors.Is(err, errPlugin) {
+		t.Fatalf("splitUnprepareResults() = %v, want an error wrapping driver-b's failure", err)
+	}
+}
+
+// A claim with no ResourceHandles never gets a batch entry, so
+// callNodePrepareResources/callNodeUnprepareResources never populate a
+// results entry for its UID. PrepareResources and unprepareResources read
+// that absent entry via a plain map index (results[claimUID]), which for a
+// map of slices yields a nil slice rather than a nil pointer — so, unlike a
+// map of pointers, indexing it is safe without an explicit presence check.
+// These pin that down against the claim UID actually used on the
+// PrepareResources/unprepareResources hot path instead of a hand-built nil.
+func TestSplitPrepareResultsMissingMapEntry(t *testing.T) {
+	results := make(map[types.UID][]*preparePluginResult)
+	claimUID := types.UID("claim-with-no-resource-handles")
+
+	succeeded, err := splitPrepareResults("default", "claim-1", results[claimUID])
+	if err != nil {
+		t.Fatalf("splitPrepareResults() unexpected error: %v", err)
+	}
+	if succeeded != nil {
+		t.Fatalf("splitPrepareResults() succeeded = %v, want nil", succeeded)
+	}
+}
+
+func TestSplitUnprepareResultsMissingMapEntry(t *testing.T) {
+	results := make(map[types.UID][]*unpreparePluginResult)
+	claimUID := types.UID("claim-with-no-resource-handles")
+
+	if err := splitUnprepareResults("default", "claim-1", results[claimUID]); err != nil {
+		t.Fatalf("splitUnprepareResults() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains metrics collected by the DRA manager in the
+// kubelet's container manager.
+package metrics
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+// DRAManagerSubsystem is the name of the subsystem used for metrics emitted
+// by the kubelet's DRA manager.
+const DRAManagerSubsystem = "dra_manager"
+
+var (
+	// OrphanedClaimsTotal tracks the number of orphaned ClaimInfo cache
+	// entries (entries whose pods no longer exist) found during the most
+	// recent reconciliation pass.
+	OrphanedClaimsTotal = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DRAManagerSubsystem,
+			Name:           "orphaned_claims",
+			Help:           "Number of ResourceClaims found to be orphaned (no corresponding active pod) during the last reconciliation pass.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// ReconcileDuration tracks how long a single reconciliation pass over
+	// the claimInfo cache takes.
+	ReconcileDuration = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      DRAManagerSubsystem,
+			Name:           "reconcile_duration_seconds",
+			Help:           "Duration in seconds of a single DRA manager reconciliation pass over the claimInfo cache.",
+			Buckets:        metrics.DefBuckets,
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// PrewarmCacheHitsTotal counts how many times PrepareResources found a
+	// fresh ResourceClaim already cached by the prewarm watcher and so
+	// skipped its own Get.
+	PrewarmCacheHitsTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      DRAManagerSubsystem,
+			Name:           "prewarm_cache_hits_total",
+			Help:           "Number of times PrepareResources used a ResourceClaim cached by the prewarm watcher instead of calling the API server.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	// PrewarmCacheMissesTotal counts how many times PrepareResources found
+	// no fresh prewarmed ResourceClaim and fell back to a direct Get.
+	PrewarmCacheMissesTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      DRAManagerSubsystem,
+			Name:           "prewarm_cache_misses_total",
+			Help:           "Number of times PrepareResources found no usable prewarmed ResourceClaim and called the API server directly.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register registers DRA manager metrics with the legacy registry.
+func Register() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(OrphanedClaimsTotal)
+		legacyregistry.MustRegister(ReconcileDuration)
+		legacyregistry.MustRegister(PrewarmCacheHitsTotal)
+		legacyregistry.MustRegister(PrewarmCacheMissesTotal)
+	})
+}
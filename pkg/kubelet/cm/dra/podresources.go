@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/dynamic-resource-allocation/resourceclaim"
+)
+
+// DynamicResourcesLister is the contract the kubelet's podresources gRPC
+// server would call through to populate the DynamicResources field of
+// ContainerResources/PodResources (List) and to stream claim
+// prepare/unprepare transitions (Watch), instead of consumers scraping the
+// DRA manager's checkpoint file. ManagerImpl satisfies this interface;
+// whether it actually returns any data is controlled by the
+// dynamicResourcesEnabled flag it was constructed with.
+//
+// "Would call through" because that server, its DynamicResources proto
+// field, and the feature gate that should control both it and
+// dynamicResourcesEnabled do not exist in this checkout (no
+// pkg/kubelet/apis/podresources package is present at all). This interface
+// and the gating below are the manager-side half of the feature, left ready
+// for that package to call into once it exists in a fuller checkout.
+type DynamicResourcesLister interface {
+	GetPodResources(pod *v1.Pod) ([]*PodResourceClaimInfo, error)
+	GetContainerResourceClaimInfo(pod *v1.Pod, container *v1.Container) ([]*PodResourceClaimInfo, error)
+	Subscribe(ctx context.Context) <-chan *PodResourceClaimEvent
+}
+
+// PodResourceClaimInfo is the subset of a ClaimInfo that is safe to surface
+// to consumers of the kubelet's podresources gRPC API (e.g. CNI plugins,
+// monitoring agents, device-aware schedulers) via DynamicResourcesLister. It
+// replaces the checkpoint-scraping pattern those consumers have historically
+// been forced to use.
+type PodResourceClaimInfo struct {
+	// ClaimName is the name of the ResourceClaim.
+	ClaimName string
+	// ClaimUID is the UID of the ResourceClaim.
+	ClaimUID types.UID
+	// DriverName is the name of the DRA driver that allocated the claim.
+	DriverName string
+	// CDIDevices are the fully-qualified CDI device names assigned to the
+	// claim by DriverName.
+	CDIDevices []string
+	// Annotations are the driver-supplied annotation key/value pairs
+	// recorded for the claim.
+	Annotations map[string]string
+}
+
+// PodResourceClaimEventType identifies whether a PodResourceClaimEvent
+// reflects a claim becoming prepared or unprepared.
+type PodResourceClaimEventType string
+
+const (
+	// PodResourceClaimEventPrepared is emitted once a claim has been
+	// successfully prepared and its CDI devices recorded in the cache.
+	PodResourceClaimEventPrepared PodResourceClaimEventType = "Prepared"
+	// PodResourceClaimEventUnprepared is emitted once a claim has been
+	// successfully unprepared and is about to be removed from the cache.
+	PodResourceClaimEventUnprepared PodResourceClaimEventType = "Unprepared"
+)
+
+// PodResourceClaimEvent is delivered to Watch subscribers whenever a claim
+// transitions between prepared and unprepared, so that consumers of the
+// podresources API can react instead of polling List.
+type PodResourceClaimEvent struct {
+	Type  PodResourceClaimEventType
+	Claim *PodResourceClaimInfo
+}
+
+// GetPodResources returns the DRA claim allocations for pod: one entry per
+// prepared claim, in the order of pod.Spec.ResourceClaims. Entries are not
+// grouped by container and do not say which containers use a claim; callers
+// that need a single container's view should use
+// GetContainerResourceClaimInfo instead. It returns no data if
+// dynamicResourcesEnabled is false.
+func (m *ManagerImpl) GetPodResources(pod *v1.Pod) ([]*PodResourceClaimInfo, error) {
+	if !m.dynamicResourcesEnabled {
+		return nil, nil
+	}
+
+	var claimInfos []*PodResourceClaimInfo
+	for i := range pod.Spec.ResourceClaims {
+		podClaim := &pod.Spec.ResourceClaims[i]
+		claimName, _, err := resourceclaim.Name(pod, podClaim)
+		if err != nil {
+			return nil, fmt.Errorf("determine resource claim information: %v", err)
+		}
+		if claimName == nil {
+			continue
+		}
+
+		info, err := m.podResourceClaimInfo(*claimName, pod.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			claimInfos = append(claimInfos, info)
+		}
+	}
+	return claimInfos, nil
+}
+
+// GetContainerResourceClaimInfo returns the DRA claim allocations for the
+// claims referenced by container. It returns no data if
+// dynamicResourcesEnabled is false.
+func (m *ManagerImpl) GetContainerResourceClaimInfo(pod *v1.Pod, container *v1.Container) ([]*PodResourceClaimInfo, error) {
+	if !m.dynamicResourcesEnabled {
+		return nil, nil
+	}
+
+	var claimInfos []*PodResourceClaimInfo
+	for i, podResourceClaim := range pod.Spec.ResourceClaims {
+		claimName, _, err := resourceclaim.Name(pod, &pod.Spec.ResourceClaims[i])
+		if err != nil {
+			return nil, fmt.Errorf("determine resource claim information: %v", err)
+		}
+		if claimName == nil {
+			continue
+		}
+
+		used := false
+		for _, c := range container.Resources.Claims {
+			if c.Name == podResourceClaim.Name {
+				used = true
+				break
+			}
+		}
+		if !used {
+			continue
+		}
+
+		info, err := m.podResourceClaimInfo(*claimName, pod.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			claimInfos = append(claimInfos, info)
+		}
+	}
+	return claimInfos, nil
+}
+
+// podResourceClaimInfo looks up claimName/namespace in the cache and
+// projects it down to the fields that are safe to surface externally. It
+// returns a nil info (with no error) if the claim has not been prepared yet.
+func (m *ManagerImpl) podResourceClaimInfo(claimName, namespace string) (*PodResourceClaimInfo, error) {
+	var info *PodResourceClaimInfo
+	err := m.cache.withRLock(func() error {
+		claimInfo, exists := m.cache.get(claimName, namespace)
+		if !exists {
+			return nil
+		}
+		if !claimInfo.isPrepared() {
+			return nil
+		}
+		info = &PodResourceClaimInfo{
+			ClaimName:   claimInfo.ClaimName,
+			ClaimUID:    claimInfo.ClaimUID,
+			DriverName:  claimInfo.DriverName,
+			CDIDevices:  claimInfo.cdiDevicesAsList(),
+			Annotations: claimInfo.annotationsAsMap(),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("locked cache operation: %w", err)
+	}
+	return info, nil
+}
+
+// annotationsAsMap projects a ClaimInfo's driver annotations down to a plain
+// map, which is the shape expected by podresources API consumers.
+func (claimInfo *ClaimInfo) annotationsAsMap() map[string]string {
+	annotations := make(map[string]string)
+	for _, a := range claimInfo.annotationsAsList() {
+		annotations[a.Name] = a.Value
+	}
+	return annotations
+}
+
+// podResourceClaimEventChanSize bounds how far a Watch subscriber can fall
+// behind before it starts missing events. Subscribers are expected to drain
+// promptly; List remains available as a consistency fallback.
+const podResourceClaimEventChanSize = 32
+
+// Subscribe registers a new Watch subscriber for claim prepare/unprepare
+// events and returns a channel that is closed when ctx is canceled. If
+// dynamicResourcesEnabled is false, the returned channel is closed
+// immediately and no events are ever sent on it.
+func (m *ManagerImpl) Subscribe(ctx context.Context) <-chan *PodResourceClaimEvent {
+	ch := make(chan *PodResourceClaimEvent, podResourceClaimEventChanSize)
+	if !m.dynamicResourcesEnabled {
+		close(ch)
+		return ch
+	}
+
+	m.subscribersMutex.Lock()
+	m.subscribers[ch] = struct{}{}
+	m.subscribersMutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.subscribersMutex.Lock()
+		delete(m.subscribers, ch)
+		m.subscribersMutex.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
+
+// publishClaimEvent notifies every Watch subscriber of a claim transition.
+// Slow subscribers are skipped rather than allowed to block the
+// prepare/unprepare path. It is a no-op if dynamicResourcesEnabled is
+// false, since Subscribe never hands out a subscribed channel in that case.
+func (m *ManagerImpl) publishClaimEvent(eventType PodResourceClaimEventType, claimInfo *ClaimInfo) {
+	if !m.dynamicResourcesEnabled {
+		return
+	}
+
+	event := &PodResourceClaimEvent{
+		Type: eventType,
+		Claim: &PodResourceClaimInfo{
+			ClaimName:   claimInfo.ClaimName,
+			ClaimUID:    claimInfo.ClaimUID,
+			DriverName:  claimInfo.DriverName,
+			CDIDevices:  claimInfo.cdiDevicesAsList(),
+			Annotations: claimInfo.annotationsAsMap(),
+		},
+	}
+
+	m.subscribersMutex.RLock()
+	defer m.subscribersMutex.RUnlock()
+	for ch := range m.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
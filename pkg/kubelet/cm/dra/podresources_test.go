@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// These cover only the dynamicResourcesEnabled short-circuit: with the gate
+// off, these methods must return before ever touching m.cache, which isn't
+// otherwise safe to exercise here without the claimInfoCache infrastructure
+// that backs a real ManagerImpl.
+func TestDynamicResourcesDisabled(t *testing.T) {
+	m := &ManagerImpl{dynamicResourcesEnabled: false}
+	pod := &v1.Pod{}
+
+	if infos, err := m.GetPodResources(pod); infos != nil || err != nil {
+		t.Errorf("GetPodResources() = %v, %v; want nil, nil when disabled", infos, err)
+	}
+
+	if infos, err := m.GetContainerResourceClaimInfo(pod, &v1.Container{}); infos != nil || err != nil {
+		t.Errorf("GetContainerResourceClaimInfo() = %v, %v; want nil, nil when disabled", infos, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := m.Subscribe(ctx)
+	if _, ok := <-ch; ok {
+		t.Errorf("Subscribe() returned a channel that is not already closed when disabled")
+	}
+}
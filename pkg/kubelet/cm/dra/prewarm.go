@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	resourceapi "k8s.io/api/resource/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	resourcev1alpha2informers "k8s.io/client-go/informers/resource/v1alpha2"
+	resourcev1alpha2listers "k8s.io/client-go/listers/resource/v1alpha2"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/cm/dra/metrics"
+)
+
+// prewarmFreshness bounds how long a ResourceClaim cached by the prewarm
+// watcher is trusted by PrepareResources without re-validating it against
+// the API server. A claim can be reallocated (picking up a new
+// ResourceVersion) between the watcher observing it and the pod reaching
+// admission; past this window PrepareResources falls back to a direct Get
+// rather than risk acting on a stale copy.
+const prewarmFreshness = 5 * time.Second
+
+// prewarmEntry is a single cached ResourceClaim plus the time it was
+// observed by the prewarm watcher.
+//
+// This is a separate map rather than a "pending prepare" claimInfoCache
+// entry: claimInfoCache entries are checkpointed and drive
+// PodMightNeedToUnprepareResources, so populating one ahead of an actual
+// PrepareResources call would need to thread a "not really prepared yet"
+// state through checkpointing and reconciliation that cache isn't designed
+// for. Keeping prewarmed entries separate confines this feature to the
+// admission-time Get it's meant to short-circuit.
+type prewarmEntry struct {
+	claim *resourceapi.ResourceClaim
+	at    time.Time
+}
+
+// StartPrewarm wires up informer-backed watchers on ResourceClaims and,
+// optionally, PodSchedulingContexts, so that claims already allocated and
+// reserved for a pod on this node are cached before PrepareResources is
+// called during admission. It must be called once, after Start has been
+// called to supply the active pods getter; pscInformer may be nil if the
+// DRAControlPlaneController feature is disabled.
+func (m *ManagerImpl) StartPrewarm(ctx context.Context, claimInformer resourcev1alpha2informers.ResourceClaimInformer, pscInformer resourcev1alpha2informers.PodSchedulingContextInformer) error {
+	logger := klog.FromContext(ctx)
+
+	m.claimLister = claimInformer.Lister()
+
+	if _, err := claimInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    m.onResourceClaimUpdate,
+		UpdateFunc: func(_, obj interface{}) { m.onResourceClaimUpdate(obj) },
+		DeleteFunc: m.onResourceClaimDelete,
+	}); err != nil {
+		return fmt.Errorf("add ResourceClaim event handler: %w", err)
+	}
+
+	if pscInformer != nil {
+		if _, err := pscInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    m.onPodSchedulingContextUpdate,
+			UpdateFunc: func(_, obj interface{}) { m.onPodSchedulingContextUpdate(obj) },
+		}); err != nil {
+			return fmt.Errorf("add PodSchedulingContext event handler: %w", err)
+		}
+	}
+
+	logger.V(2).Info("DRA manager prewarm watchers started")
+	return nil
+}
+
+// onResourceClaimUpdate caches claim if it has been allocated and reserved
+// for a pod this kubelet currently considers active, so that
+// PrepareResources can pick it up via prewarmedClaim instead of doing its
+// own Get. Claims for pods not (yet) known to be active are ignored; they
+// will be picked up by a later update once the pod is active, or by
+// PrepareResources' own Get if admission races ahead of the watcher.
+func (m *ManagerImpl) onResourceClaimUpdate(obj interface{}) {
+	claim, ok := obj.(*resourceapi.ResourceClaim)
+	if !ok || claim.Status.Allocation == nil || !m.reservedForActivePod(claim) {
+		return
+	}
+
+	m.prewarmMu.Lock()
+	defer m.prewarmMu.Unlock()
+	m.prewarmed[claim.Namespace+"/"+claim.Name] = prewarmEntry{claim: claim, at: time.Now()}
+}
+
+// onResourceClaimDelete evicts claim's entry from m.prewarmed, if any. Without
+// this, claims generated from ResourceClaimTemplates (whose names are unique
+// per pod) would leave their cache entry behind forever once the pod
+// terminates and the claim is garbage-collected.
+func (m *ManagerImpl) onResourceClaimDelete(obj interface{}) {
+	claim, ok := obj.(*resourceapi.ResourceClaim)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		claim, ok = tombstone.Obj.(*resourceapi.ResourceClaim)
+		if !ok {
+			return
+		}
+	}
+
+	m.prewarmMu.Lock()
+	defer m.prewarmMu.Unlock()
+	delete(m.prewarmed, claim.Namespace+"/"+claim.Name)
+}
+
+// onPodSchedulingContextUpdate observes PodSchedulingContexts that have
+// been assigned to this node by the scheduler. It does not itself populate
+// the claimInfo cache — the ResourceClaims the scheduler allocates as a
+// result are picked up by onResourceClaimUpdate once they appear — but it
+// gives operators a point-in-time signal, via logging, of how far ahead of
+// allocation the scheduler's decision for this node was made.
+func (m *ManagerImpl) onPodSchedulingContextUpdate(obj interface{}) {
+	psc, ok := obj.(*resourceapi.PodSchedulingContext)
+	if !ok || types.NodeName(psc.Spec.SelectedNode) != m.nodeName {
+		return
+	}
+	klog.V(5).InfoS("PodSchedulingContext selected this node", "podSchedulingContext", klog.KObj(psc))
+}
+
+// reservedForActivePod reports whether claim's Status.ReservedFor includes
+// a pod UID that m.activePods currently considers active.
+func (m *ManagerImpl) reservedForActivePod(claim *resourceapi.ResourceClaim) bool {
+	if m.activePods == nil {
+		return false
+	}
+	activePods := m.activePods()
+	for _, reserved := range claim.Status.ReservedFor {
+		for _, pod := range activePods {
+			if reserved.UID == pod.UID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prewarmedClaim returns the ResourceClaim the prewarm watcher cached for
+// claimName/namespace, or nil if there is none, if it is older than
+// prewarmFreshness, or if claimLister's local informer cache — which is
+// driven by the same watch as the prewarm handlers but is read fresh on
+// every call rather than only on Add/Update — has since observed a newer
+// ResourceVersion for the claim. That last check catches the case where an
+// Add/Update event is still queued behind other work on the informer's
+// single worker, so the cached copy would otherwise be served stale. In
+// either stale case the caller must fall back to a direct API Get.
+func (m *ManagerImpl) prewarmedClaim(claimName, namespace string) *resourceapi.ResourceClaim {
+	m.prewarmMu.RLock()
+	entry, exists := m.prewarmed[namespace+"/"+claimName]
+	m.prewarmMu.RUnlock()
+
+	if !exists || time.Since(entry.at) > prewarmFreshness {
+		return nil
+	}
+
+	if m.claimLister != nil {
+		if current, err := m.claimLister.ResourceClaims(namespace).Get(claimName); err == nil {
+			if resourceVersionOlder(entry.claim.ResourceVersion, current.ResourceVersion) {
+				return nil
+			}
+		}
+	}
+
+	return entry.claim
+}
+
+// resourceVersionOlder reports whether cached is an earlier ResourceVersion
+// than current. ResourceVersions are opaque strings in general, but
+// client-go's informer caches (like the one behind claimLister) always
+// populate them from etcd's mod-revision, which increases monotonically and
+// is lexically comparable once padded to equal length.
+func resourceVersionOlder(cached, current string) bool {
+	if cached == current {
+		return false
+	}
+	if len(cached) != len(current) {
+		return len(cached) < len(current)
+	}
+	return cached < current
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	resourcev1alpha2listers "k8s.io/client-go/listers/resource/v1alpha2"
+)
+
+func TestResourceVersionOlder(t *testing.T) {
+	for name, test := range map[string]struct {
+		cached, current string
+		want             bool
+	}{
+		"equal":                 {cached: "100", current: "100", want: false},
+		"cached behind":         {cached: "100", current: "101", want: true},
+		"cached ahead":          {cached: "101", current: "100", want: false},
+		"cached behind, padded": {cached: "99", current: "100", want: true},
+		"cached ahead, padded":  {cached: "100", current: "99", want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := resourceVersionOlder(test.cached, test.current); got != test.want {
+				t.Errorf("resourceVersionOlder(%q, %q) = %v, want %v", test.cached, test.current, got, test.want)
+			}
+		})
+	}
+}
+
+func TestReservedForActivePod(t *testing.T) {
+	activePodUID := types.UID("active-pod")
+	inactivePodUID := types.UID("inactive-pod")
+
+	for name, test := range map[string]struct {
+		activePods ActivePodsFunc
+		claim      *resourceapi.ResourceClaim
+		want       bool
+	}{
+		"no active pods getter": {
+			activePods: nil,
+			claim:      claimReservedFor(activePodUID),
+			want:       false,
+		},
+		"reserved for an active pod": {
+			activePods: func() []*v1.Pod { return []*v1.Pod{{ObjectMeta: metav1.ObjectMeta{UID: activePodUID}}} },
+			claim:      claimReservedFor(activePodUID),
+			want:       true,
+		},
+		"reserved only for an inactive pod": {
+			activePods: func() []*v1.Pod { return []*v1.Pod{{ObjectMeta: metav1.ObjectMeta{UID: activePodUID}}} },
+			claim:      claimReservedFor(inactivePodUID),
+			want:       false,
+		},
+		"reserved for nobody": {
+			activePods: func() []*v1.Pod { return []*v1.Pod{{ObjectMeta: metav1.ObjectMeta{UID: activePodUID}}} },
+			claim:      claimReservedFor(),
+			want:       false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := &ManagerImpl{activePods: test.activePods}
+			if got := m.reservedForActivePod(test.claim); got != test.want {
+				t.Errorf("reservedForActivePod() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func claimReservedFor(uids ...types.UID) *resourceapi.ResourceClaim {
+	claim := &resourceapi.ResourceClaim{}
+	for _, uid := range uids {
+		claim.Status.ReservedFor = append(claim.Status.ReservedFor, resourceapi.ResourceClaimConsumerReference{UID: uid})
+	}
+	return claim
+}
+
+// TestPrewarmedClaimResourceVersionResync covers the scenario the freshness
+// window alone can't: a claim is reallocated (and so picks up a new
+// ResourceVersion) within prewarmFreshness of being cached. A cached entry
+// that is still within the freshness window but behind claimLister's view
+// must be treated as stale so the caller falls back to a direct Get,
+// exactly as if the entry had expired.
+func TestPrewarmedClaimResourceVersionResync(t *testing.T) {
+	const namespace, name = "default", "claim-1"
+
+	for testName, test := range map[string]struct {
+		cachedVersion string
+		listerVersion string
+		listerErr     error
+		wantNilResult bool
+	}{
+		"cached matches lister's view": {
+			cachedVersion: "100",
+			listerVersion: "100",
+			wantNilResult: false,
+		},
+		"lister has observed a newer version": {
+			cachedVersion: "100",
+			listerVersion: "101",
+			wantNilResult: true,
+		},
+		"lister lookup fails, cached entry still used": {
+			cachedVersion: "100",
+			listerErr:     errClaimNotFound,
+			wantNilResult: false,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			cachedClaim := &resourceapi.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, ResourceVersion: test.cachedVersion},
+			}
+			currentClaim := &resourceapi.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, ResourceVersion: test.listerVersion},
+			}
+
+			m := &ManagerImpl{
+				prewarmed:   map[string]prewarmEntry{namespace + "/" + name: {claim: cachedClaim, at: time.Now()}},
+				claimLister: &fakeResourceClaimLister{claim: currentClaim, err: test.listerErr},
+			}
+
+			got := m.prewarmedClaim(name, namespace)
+			if (got == nil) != test.wantNilResult {
+				t.Errorf("prewarmedClaim() = %v, want nil = %v", got, test.wantNilResult)
+			}
+		})
+	}
+}
+
+var errClaimNotFound = stderrors.New("resourceclaim not found")
+
+var _ resourcev1alpha2listers.ResourceClaimLister = &fakeResourceClaimLister{}
+var _ resourcev1alpha2listers.ResourceClaimNamespaceLister = &fakeResourceClaimNamespaceLister{}
+
+type fakeResourceClaimLister struct {
+	claim *resourceapi.ResourceClaim
+	err   error
+}
+
+func (f *fakeResourceClaimLister) List(selector labels.Selector) ([]*resourceapi.ResourceClaim, error) {
+	return nil, nil
+}
+
+func (f *fakeResourceClaimLister) ResourceClaims(namespace string) resourcev1alpha2listers.ResourceClaimNamespaceLister {
+	return &fakeResourceClaimNamespaceLister{claim: f.claim, err: f.err}
+}
+
+type fakeResourceClaimNamespaceLister struct {
+	claim *resourceapi.ResourceClaim
+	err   error
+}
+
+func (f *fakeResourceClaimNamespaceLister) List(selector labels.Selector) ([]*resourceapi.ResourceClaim, error) {
+	return nil, nil
+}
+
+func (f *fakeResourceClaimNamespaceLister) Get(name string) (*resourceapi.ResourceClaim, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.claim, nil
+}
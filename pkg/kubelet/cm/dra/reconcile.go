@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/cm/dra/metrics"
+)
+
+// reconcileJitterFactor adds up to 10% jitter to reconcilePeriod so that,
+// across a fleet of nodes, reconciliation passes don't all line up and hit
+// the API server or DRA plugins at the same instant.
+const reconcileJitterFactor = 0.1
+
+// Reconcile runs the DRA manager's orphaned-claim reconciliation loop until
+// ctx is canceled. Start runs it as a goroutine after supplying the active
+// pods getter.
+func (m *ManagerImpl) Reconcile(ctx context.Context) {
+	if m.activePods == nil {
+		klog.FromContext(ctx).Error(nil, "DRA manager reconciliation loop started without an active pods getter, not running")
+		return
+	}
+	wait.JitterUntil(func() {
+		m.reconcileOnce(ctx)
+	}, m.reconcilePeriod, reconcileJitterFactor, true, ctx.Done())
+}
+
+// reconcileOnce walks the claimInfo cache once, looking for entries whose
+// PodUIDs no longer correspond to any active pod, and unprepares the
+// resources for those orphaned references.
+//
+// Untested: the orphan-grouping logic here is exercised through
+// m.cache.allClaimInfo and m.unprepareResources, both of which depend on the
+// claimInfoCache, so it needs a fake plugin client and a populated cache to
+// drive rather than a pure table test; see splitUnprepareResults's tests for
+// the per-claim error isolation this feeds into.
+func (m *ManagerImpl) reconcileOnce(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	start := time.Now()
+	defer func() {
+		metrics.ReconcileDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	activeUIDs := make(map[types.UID]bool)
+	for _, pod := range m.activePods() {
+		activeUIDs[pod.UID] = true
+	}
+
+	type orphan struct {
+		namespace  string
+		claimNames []string
+	}
+	orphansByPod := make(map[types.UID]*orphan)
+	orphanedClaims := 0
+
+	m.cache.RLock()
+	for _, claimInfo := range m.cache.allClaimInfo() {
+		orphaned := false
+		for podUID := range claimInfo.PodUIDs {
+			if activeUIDs[podUID] {
+				continue
+			}
+			orphaned = true
+			o, exists := orphansByPod[podUID]
+			if !exists {
+				o = &orphan{namespace: claimInfo.Namespace}
+				orphansByPod[podUID] = o
+			}
+			o.claimNames = append(o.claimNames, claimInfo.ClaimName)
+		}
+		if orphaned {
+			orphanedClaims++
+		}
+	}
+	m.cache.RUnlock()
+
+	for podUID, o := range orphansByPod {
+		logger.V(2).Info("Unpreparing resources for orphaned claims", "podUID", podUID, "namespace", o.namespace, "claims", o.claimNames)
+		if err := m.unprepareResources(podUID, o.namespace, o.claimNames); err != nil {
+			logger.Error(err, "Failed to unprepare resources for orphaned claims", "podUID", podUID, "namespace", o.namespace, "claims", o.claimNames)
+		}
+	}
+	metrics.OrphanedClaimsTotal.Set(float64(orphanedClaims))
+}
+
+// allClaimInfo returns a snapshot of every ClaimInfo currently in the cache.
+// Callers must hold (at least) a read lock on the cache.
+func (cache *claimInfoCache) allClaimInfo() []*ClaimInfo {
+	claimInfo := make([]*ClaimInfo, 0, len(cache.claimInfo))
+	for _, info := range cache.claimInfo {
+		claimInfo = append(claimInfo, info)
+	}
+	return claimInfo
+}